@@ -0,0 +1,227 @@
+/*
+AUTHOR: Patrick Archer (@pjarcher913)
+DATE CREATED: 29 July 2026
+Copyright (c) 2026 Patrick Archer
+*/
+
+/*
+This file holds the application's runtime configuration: values that are allowed to vary between
+environments (port, timeouts, ...) without a recompile. Precedence is config.json < environment
+variables, so ops can override a checked-in default for a single deploy without editing the file.
+*/
+package main
+
+import (
+	"encoding/json"
+	log "github.com/sirupsen/logrus"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*====================================================================================================================*/
+
+// Where the optional JSON config overrides file lives, relative to the working directory.
+const CONFIG_PATH = "./config.json"
+
+// ServerConfig holds everything initWebServer() needs to stand up the *http.Server.
+type ServerConfig struct {
+	Port            string        `json:"server_port"`
+	ReadTimeout     time.Duration `json:"read_timeout"`
+	WriteTimeout    time.Duration `json:"write_timeout"`
+	IdleTimeout     time.Duration `json:"idle_timeout"`
+	ShutdownGrace   time.Duration `json:"shutdown_grace"`
+}
+
+// configFile mirrors ServerConfig but with durations expressed as plain seconds, since encoding/json
+// can't unmarshal directly into time.Duration from a human-friendly number.
+type configFile struct {
+	Port          string `json:"server_port"`
+	ReadTimeoutS  int    `json:"read_timeout_seconds"`
+	WriteTimeoutS int    `json:"write_timeout_seconds"`
+	IdleTimeoutS  int    `json:"idle_timeout_seconds"`
+	ShutdownGraceS int   `json:"shutdown_grace_seconds"`
+}
+
+// RouteConfig holds values that affect how the app is mounted, so it can sit behind a reverse proxy
+// at a non-root URL instead of always assuming it owns the whole origin.
+type RouteConfig struct {
+	// BasePath is prepended to every route (e.g. "/nexus") and to every link/asset URL rendered into
+	// a page. Empty means the app is mounted at the root, as before.
+	BasePath string
+	// TrustedProxies lists the RemoteAddr IPs allowed to set X-Forwarded-Proto/X-Forwarded-Host for
+	// URL generation. Requests from anywhere else have those headers ignored.
+	TrustedProxies []string
+}
+
+// routeConfigFile is the "route" section of config.json.
+type routeConfigFile struct {
+	Route struct {
+		BasePath       string   `json:"base_path"`
+		TrustedProxies []string `json:"trusted_proxies"`
+	} `json:"route"`
+}
+
+// CORSConfig controls the Access-Control-Allow-Origin policy corsMiddleware() applies (see
+// middleware.go). AllowedOrigins defaults to ["*"] so existing deploys keep working unchanged;
+// set it to a specific allow-list to stop allowing every origin.
+type CORSConfig struct {
+	AllowedOrigins []string
+}
+
+// corsConfigFile is the "cors" section of config.json.
+type corsConfigFile struct {
+	CORS struct {
+		AllowedOrigins []string `json:"allowed_origins"`
+	} `json:"cors"`
+}
+
+/*====================================================================================================================*/
+
+// defaultServerConfig() returns the built-in fallback values, used when neither config.json nor the
+// matching environment variables are present.
+func defaultServerConfig() ServerConfig {
+	return ServerConfig{
+		Port:          SERVER_PORT,
+		ReadTimeout:   5 * time.Second,
+		WriteTimeout:  10 * time.Second,
+		IdleTimeout:   120 * time.Second,
+		ShutdownGrace: 10 * time.Second,
+	}
+}
+
+// loadServerConfig() builds the effective ServerConfig by layering config.json over the defaults,
+// then letting SERVER_PORT/SERVER_READ_TIMEOUT/SERVER_WRITE_TIMEOUT/SERVER_IDLE_TIMEOUT/SERVER_SHUTDOWN_GRACE
+// environment variables (seconds, except SERVER_PORT) take the final say.
+func loadServerConfig() ServerConfig {
+	cfg := defaultServerConfig()
+
+	if raw, err := os.ReadFile(CONFIG_PATH); err == nil {
+		var fileCfg configFile
+		if err := json.Unmarshal(raw, &fileCfg); err != nil {
+			log.WithError(err).Warnln("Failed to parse config.json, ignoring it.")
+		} else {
+			applyConfigFile(&cfg, fileCfg)
+		}
+	}
+
+	applyEnvOverrides(&cfg)
+
+	return cfg
+}
+
+// applyConfigFile() copies any non-zero fields from a parsed config.json into cfg.
+func applyConfigFile(cfg *ServerConfig, fileCfg configFile) {
+	if fileCfg.Port != "" {
+		cfg.Port = fileCfg.Port
+	}
+	if fileCfg.ReadTimeoutS > 0 {
+		cfg.ReadTimeout = time.Duration(fileCfg.ReadTimeoutS) * time.Second
+	}
+	if fileCfg.WriteTimeoutS > 0 {
+		cfg.WriteTimeout = time.Duration(fileCfg.WriteTimeoutS) * time.Second
+	}
+	if fileCfg.IdleTimeoutS > 0 {
+		cfg.IdleTimeout = time.Duration(fileCfg.IdleTimeoutS) * time.Second
+	}
+	if fileCfg.ShutdownGraceS > 0 {
+		cfg.ShutdownGrace = time.Duration(fileCfg.ShutdownGraceS) * time.Second
+	}
+}
+
+// applyEnvOverrides() lets environment variables win over config.json/defaults, which keeps
+// container-style deploys (where env vars are the norm) simple without needing a config.json at all.
+func applyEnvOverrides(cfg *ServerConfig) {
+	if v := os.Getenv("SERVER_PORT"); v != "" {
+		cfg.Port = v
+	}
+	if v := envSeconds("SERVER_READ_TIMEOUT"); v > 0 {
+		cfg.ReadTimeout = v
+	}
+	if v := envSeconds("SERVER_WRITE_TIMEOUT"); v > 0 {
+		cfg.WriteTimeout = v
+	}
+	if v := envSeconds("SERVER_IDLE_TIMEOUT"); v > 0 {
+		cfg.IdleTimeout = v
+	}
+	if v := envSeconds("SERVER_SHUTDOWN_GRACE"); v > 0 {
+		cfg.ShutdownGrace = v
+	}
+}
+
+// defaultRouteConfig() returns the built-in fallback values: mounted at the root, no trusted proxies.
+func defaultRouteConfig() RouteConfig {
+	return RouteConfig{}
+}
+
+// loadRouteConfig() builds the effective RouteConfig by layering config.json's "route" section over
+// the defaults, then letting ROUTE_BASE_PATH/ROUTE_TRUSTED_PROXIES (comma-separated) take the final say.
+func loadRouteConfig() RouteConfig {
+	cfg := defaultRouteConfig()
+
+	if raw, err := os.ReadFile(CONFIG_PATH); err == nil {
+		var fileCfg routeConfigFile
+		if err := json.Unmarshal(raw, &fileCfg); err != nil {
+			log.WithError(err).Warnln("Failed to parse config.json, ignoring it.")
+		} else {
+			if fileCfg.Route.BasePath != "" {
+				cfg.BasePath = fileCfg.Route.BasePath
+			}
+			if len(fileCfg.Route.TrustedProxies) > 0 {
+				cfg.TrustedProxies = fileCfg.Route.TrustedProxies
+			}
+		}
+	}
+
+	if v := os.Getenv("ROUTE_BASE_PATH"); v != "" {
+		cfg.BasePath = v
+	}
+	if v := os.Getenv("ROUTE_TRUSTED_PROXIES"); v != "" {
+		cfg.TrustedProxies = strings.Split(v, ",")
+	}
+
+	return cfg
+}
+
+// defaultCORSConfig() returns the built-in fallback values: every origin allowed, matching the
+// wildcard policy this app has always shipped with.
+func defaultCORSConfig() CORSConfig {
+	return CORSConfig{AllowedOrigins: []string{"*"}}
+}
+
+// loadCORSConfig() builds the effective CORSConfig by layering config.json's "cors" section over
+// the defaults, then letting CORS_ALLOWED_ORIGINS (comma-separated) take the final say.
+func loadCORSConfig() CORSConfig {
+	cfg := defaultCORSConfig()
+
+	if raw, err := os.ReadFile(CONFIG_PATH); err == nil {
+		var fileCfg corsConfigFile
+		if err := json.Unmarshal(raw, &fileCfg); err != nil {
+			log.WithError(err).Warnln("Failed to parse config.json, ignoring it.")
+		} else if len(fileCfg.CORS.AllowedOrigins) > 0 {
+			cfg.AllowedOrigins = fileCfg.CORS.AllowedOrigins
+		}
+	}
+
+	if v := os.Getenv("CORS_ALLOWED_ORIGINS"); v != "" {
+		cfg.AllowedOrigins = strings.Split(v, ",")
+	}
+
+	return cfg
+}
+
+// envSeconds() reads an environment variable as a count of seconds and returns it as a Duration,
+// or 0 if the variable is unset/unparseable.
+func envSeconds(name string) time.Duration {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return 0
+	}
+	seconds, err := strconv.Atoi(raw)
+	if err != nil {
+		log.WithField("env", name).WithError(err).Warnln("Failed to parse duration env var, ignoring it.")
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
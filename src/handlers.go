@@ -0,0 +1,84 @@
+/*
+AUTHOR: Patrick Archer (@pjarcher913)
+DATE CREATED: 10 April 2020
+Copyright (c) 2020 Patrick Archer
+*/
+
+/*
+Page and route handler for every handler not large enough to warrant its own file.
+*/
+package main
+
+import (
+	"encoding/json"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+	"time"
+)
+
+/*====================================================================================================================*/
+
+// Used for sending responses to clients when server receives POST to /api/v1/{rootParam}
+type POST_Home_Response struct {
+	// Define properties of struct
+	Message 	string	`json:"msg"`
+	Parameter 	string	`json:"param"`
+	Timestamp 	string	`json:"time"`
+}
+
+/*====================================================================================================================*/
+
+// prh_GET_Home() is the website's "Home" page GET route handler.
+func prh_GET_Home(w http.ResponseWriter, r *http.Request) {
+	entry := FromContext(r.Context())
+	entry.Infoln("Executing prh_GET_Home().")
+
+	data := PageData{
+		Title:        "project-nexus",
+		BasePath:     routeConfig.BasePath,
+		BuildVersion: BuildVersion,
+		CSRFToken:    newCSRFToken(),
+	}
+
+	if err := renderer.Render(w, "home", data); err != nil {
+		entry.WithError(err).Errorln("Failed to render home page.")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// prh_POST_Home() is the "Home" page's API route handler, mounted under /api/v1/{rootParam}.
+// It simply returns basic info that was parsed from the web request.
+func prh_POST_Home(w http.ResponseWriter, r *http.Request) {
+	entry := FromContext(r.Context())
+	entry.Infoln("Executing prh_POST_Home(), which is an Easter Egg!")
+	w.Header().Set("Content-Type", "application/json")
+
+	// Get raw request URL path
+	reqUrl := r.URL
+
+	// Get request params
+	params := mux.Vars(r)
+
+	// Populate response struct
+	response := POST_Home_Response{
+		Message:   "Hey, you found an API Easter Egg!",
+		Parameter: params["rootParam"],
+		Timestamp: time.Now().UTC().String(),
+	}
+
+	// Log response
+	entry.WithFields(log.Fields{
+		"responseData": response,
+		"allParams": params,
+		"fullURL": reqUrl,
+	}).Debug("RESPONSE-prh_POST_Home()")
+
+	// Encode response as JSON and send to client via http.ResponseWriter. A failed encode here means
+	// the connection is likely already broken; log it and return a 500 rather than crashing the server.
+	encodingErr := json.NewEncoder(w).Encode(response)
+	if encodingErr != nil {
+		entry.WithError(encodingErr).Errorln("Failed to encode prh_POST_Home() response.")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
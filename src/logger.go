@@ -0,0 +1,54 @@
+/*
+AUTHOR: Patrick Archer (@pjarcher913)
+DATE CREATED: 29 July 2026
+Copyright (c) 2026 Patrick Archer
+*/
+
+/*
+Request-scoped logging helpers. requestIDMiddleware() (see middleware.go) stashes a request ID,
+method, path, remote addr, and start time on every request's context; FromContext() turns those into
+a *logrus.Entry pre-populated with request_id/method/path/remote_addr/elapsed so every log line for a
+single request can be correlated in the JSON output.
+
+This lives as FromContext(ctx context.Context) in package main rather than as logger.FromContext() in
+a separate logger package. There's no go.mod in this tree yet, so there's no module path for a
+sub-package to live under or be imported by — splitting main into packages isn't viable until that
+exists. The moment a module is introduced, this is the natural first thing to carve out: move this
+file (unchanged, modulo the import path) into a logger package and update its call sites in
+handlers.go and realtime.go.
+*/
+package main
+
+import (
+	"context"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+/*====================================================================================================================*/
+
+// FromContext() returns a *logrus.Entry scoped to the in-flight request carried by ctx, pre-populated
+// with request_id, method, path, remote_addr, and the elapsed time since the request started. Falls
+// back to a bare entry (no request_id) if called with a context that didn't go through
+// requestIDMiddleware().
+func FromContext(ctx context.Context) *log.Entry {
+	fields := log.Fields{}
+
+	if id, ok := ctx.Value(ctxKeyRequestID).(string); ok {
+		fields["request_id"] = id
+	}
+	if method, ok := ctx.Value(ctxKeyMethod).(string); ok {
+		fields["method"] = method
+	}
+	if path, ok := ctx.Value(ctxKeyPath).(string); ok {
+		fields["path"] = path
+	}
+	if remoteAddr, ok := ctx.Value(ctxKeyRemoteAddr).(string); ok {
+		fields["remote_addr"] = remoteAddr
+	}
+	if start, ok := ctx.Value(ctxKeyStartTime).(time.Time); ok {
+		fields["elapsed"] = time.Since(start).String()
+	}
+
+	return log.WithFields(fields)
+}
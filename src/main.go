@@ -11,13 +11,8 @@ It runs a preliminary setup routine that, after successful completion, initializ
 package main
 
 import (
-	"encoding/json"
-	"fmt"
-	"github.com/gorilla/mux"
 	log "github.com/sirupsen/logrus"
-	"net/http"
 	"os"
-	"time"
 )
 
 /*====================================================================================================================*/
@@ -31,20 +26,20 @@ const LOG_PATH = "./logs/"
 // Unique id tag included into newly-generated log file names
 var LOG_STAMP = "pn-main"
 
-// Host port to serve on
+// Default host port to serve on, used unless overridden by config.json or SERVER_PORT (see config.go)
 const SERVER_PORT = ":3000"
 
-const (
-	PATH_TO_HOME_HTML = "./src/web/pages/home/home.html" // Location of home.html (used to render page)
-)
+// Handle to the open log file, kept around so it can be flushed/closed via an OnShutdown hook
+var logFile *os.File
 
-// Used for sending responses to clients when server receives POST to home URL
-type POST_Home_Response struct {
-	// Define properties of struct
-	Message 	string	`json:"msg"`
-	Parameter 	string	`json:"param"`
-	Timestamp 	string	`json:"time"`
-}
+// Shared page renderer, built once in prelimSetup() and used by every HTML page handler
+var renderer *Renderer
+
+// Route/reverse-proxy configuration, loaded once in prelimSetup() and used by initRouter()
+var routeConfig RouteConfig
+
+// CORS policy, loaded once in prelimSetup() and used by initRouter() to configure corsMiddleware()
+var corsConfig CORSConfig
 
 /*====================================================================================================================*/
 
@@ -70,7 +65,7 @@ func initLogger() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	//defer file.Close()  // Because file will be closed in GC, we can leave it open so the logger is uninterrupted
+	logFile = file
 	log.SetOutput(file)
 	log.SetFormatter(&log.JSONFormatter{})
 
@@ -81,90 +76,30 @@ func initLogger() {
 }
 
 // prelimSetup() performs all preliminary setups before server goes live and starts listening for requests.
+// It also registers the cleanup funcs that should run when initWebServer() shuts down gracefully.
 func prelimSetup() bool {
 	// Initialize logger
 	initLogger()
 
-	// Services init'd, thus prelimSetup() is complete
-	log.Println("Initializing services via prelimSetup().")
-	return true
-}
-
-/*====================================================================================================================*/
-
-// initRouter() initializes Mux's routing services and configures them according to the website's page route hierarchy.
-func initRouter() *mux.Router {
-	log.Infoln("Executing initRouter().")
-
-	// Init mux router object
-	r := mux.NewRouter()
-
-	/* Init route handlers */
-
-	// 404
-	// TODO: Custom 404 error route handler
-	//r.NotFoundHandler = http.HandlerFunc(prh_404)
-
-	// GETs
-	r.HandleFunc("/", prh_GET_Home).Methods("GET")
+	// Flush and close the log file once the server stops accepting requests
+	OnShutdown(func() {
+		if logFile != nil {
+			_ = logFile.Close()
+		}
+	})
 
-	// POSTs
-	r.HandleFunc("/{rootParam}", prh_POST_Home).Methods("POST")
+	// Build the shared page renderer (re-parses templates per request in DEBUG_MODE)
+	renderer = NewRenderer(DEBUG_MODE)
 
-	return r
-}
-
-// initWebServer() initializes the web server and begins serving clients connecting to the pre-configured SERVER_PORT
-func initWebServer(routeHandler *mux.Router) {
-	log.Infoln("Executing initWebServer().")
+	// Load route/reverse-proxy configuration ahead of initRouter()
+	routeConfig = loadRouteConfig()
 
-	// Serve website and listen on configured SERVER_PORT
-	// http.ListenAndServe() always returns a non-nil error, and the error is its only returned value.
-	// However, http.ListenAndServe() should never return (unless error or intentional termination).
-	fmt.Println("Now serving on 127.0.0.1" + SERVER_PORT)
-	log.Infoln("Now serving on 127.0.0.1" + SERVER_PORT)
-	err := http.ListenAndServe(SERVER_PORT, routeHandler)
-	if err != nil {
-		log.Fatalln(err.Error())
-	}
-}
+	// Load CORS policy ahead of initRouter()
+	corsConfig = loadCORSConfig()
 
-// prh_GET_Home() is the website's "Home" page GET route handler.
-func prh_GET_Home(w http.ResponseWriter, r *http.Request) {
-	log.Infoln("Executing prh_GET_Home().")
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	http.ServeFile(w, r, PATH_TO_HOME_HTML)
+	// Services init'd, thus prelimSetup() is complete
+	log.Println("Initializing services via prelimSetup().")
+	MarkReady()
+	return true
 }
 
-// prh_POST_Home() is the website's "Home" page POST route handler.
-// It simply returns basic info that was parsed from the web request.
-func prh_POST_Home(w http.ResponseWriter, r *http.Request) {
-	log.Infoln("Executing prh_POST_Home(), which is an Easter Egg!")
-	w.Header().Set("Content-Type", "application/json")
-
-	// Get raw request URL path
-	reqUrl := r.URL
-
-	// Get request params
-	params := mux.Vars(r)
-
-	// Populate response struct
-	response := POST_Home_Response{
-		Message:   "Hey, you found an API Easter Egg!",
-		Parameter: params["rootParam"],
-		Timestamp: time.Now().UTC().String(),
-	}
-
-	// Log response
-	log.WithFields(log.Fields{
-		"responseData": response,
-		"allParams": params,
-		"fullURL": reqUrl,
-	}).Debug("RESPONSE-prh_POST_Home()")
-
-	// Encode response as JSON and send to client via http.ResponseWriter
-	encodingErr := json.NewEncoder(w).Encode(response)
-	if encodingErr != nil {
-		log.Fatalln(encodingErr.Error())
-	}
-}
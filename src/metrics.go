@@ -0,0 +1,168 @@
+/*
+AUTHOR: Patrick Archer (@pjarcher913)
+DATE CREATED: 29 July 2026
+Copyright (c) 2026 Patrick Archer
+*/
+
+/*
+Observability subsystem: a /metrics endpoint for Prometheus scraping, plus /healthz (liveness) and
+/readyz (readiness, gated on prelimSetup() having finished and any registered readiness probes
+passing). RegisterMetric() lets feature packages add their own collectors the same way
+RegisterRoutes() lets them add their own routes.
+*/
+package main
+
+import (
+	"bufio"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+/*====================================================================================================================*/
+
+// ReadinessProbe reports whether some dependency the app relies on (a DB connection, a downstream
+// service, ...) is currently usable. /readyz returns 503 unless every registered probe passes.
+type ReadinessProbe func() bool
+
+var (
+	readyMu         sync.RWMutex
+	appReady        bool
+	readinessProbes []ReadinessProbe
+)
+
+var (
+	httpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total HTTP requests processed, labeled by method, matched route path, and status code.",
+	}, []string{"method", "path", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency in seconds, labeled by method and matched route path.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "path"})
+)
+
+func init() {
+	prometheus.MustRegister(httpRequestsTotal, httpRequestDuration)
+
+	// Registered as root routes, not app routes: Kubernetes probes and Prometheus scrape configs
+	// talk to the pod directly, not through the reverse proxy routeConfig.BasePath exists for.
+	RegisterRootRoutes(func(r *mux.Router) {
+		r.Handle("/metrics", promhttp.Handler()).Methods("GET")
+		r.HandleFunc("/healthz", prh_GET_Healthz).Methods("GET")
+		r.HandleFunc("/readyz", prh_GET_Readyz).Methods("GET")
+	})
+}
+
+/*====================================================================================================================*/
+
+// RegisterMetric() registers a Prometheus collector with the default registry, mirroring
+// RegisterRoutes()'s role for routes: feature packages add their own counters/gauges without
+// editing this file.
+func RegisterMetric(collector prometheus.Collector) error {
+	return prometheus.Register(collector)
+}
+
+// MarkReady() flips the app into "ready", called once prelimSetup() has finished initializing
+// every service. Before this is called, /readyz always returns 503.
+func MarkReady() {
+	readyMu.Lock()
+	defer readyMu.Unlock()
+	appReady = true
+}
+
+// AddReadinessProbe() registers an additional condition that must hold for /readyz to report ready.
+func AddReadinessProbe(probe ReadinessProbe) {
+	readinessProbes = append(readinessProbes, probe)
+}
+
+// isReady() reports whether prelimSetup() has completed and every registered readiness probe passes.
+func isReady() bool {
+	readyMu.RLock()
+	ready := appReady
+	readyMu.RUnlock()
+
+	if !ready {
+		return false
+	}
+	for _, probe := range readinessProbes {
+		if !probe() {
+			return false
+		}
+	}
+	return true
+}
+
+/*====================================================================================================================*/
+
+// metricsMiddleware() records http_requests_total and http_request_duration_seconds for every
+// request, keyed by the matched mux.Route template (not the raw URL) so path params like
+// {rootParam} don't blow up cardinality.
+func metricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r)
+
+		path := r.URL.Path
+		if route := mux.CurrentRoute(r); route != nil {
+			if tmpl, err := route.GetPathTemplate(); err == nil {
+				path = tmpl
+			}
+		}
+
+		httpRequestsTotal.WithLabelValues(r.Method, path, strconv.Itoa(rec.status)).Inc()
+		httpRequestDuration.WithLabelValues(r.Method, path).Observe(time.Since(start).Seconds())
+	})
+}
+
+// statusRecorder wraps an http.ResponseWriter to capture the status code written to it, since
+// http.ResponseWriter doesn't expose what was sent after the fact.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (rec *statusRecorder) WriteHeader(code int) {
+	rec.status = code
+	rec.ResponseWriter.WriteHeader(code)
+}
+
+// Flush() and Hijack() pass through to the wrapped writer, so the SSE and WebSocket handlers in
+// realtime.go keep working behind metricsMiddleware().
+func (rec *statusRecorder) Flush() {
+	if f, ok := rec.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (rec *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(rec.ResponseWriter).Hijack()
+}
+
+/*====================================================================================================================*/
+
+// prh_GET_Healthz() is the liveness probe: if the process can respond at all, it's alive.
+func prh_GET_Healthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
+
+// prh_GET_Readyz() is the readiness probe: 503 until prelimSetup() has finished and every
+// registered readiness probe passes, 200 otherwise.
+func prh_GET_Readyz(w http.ResponseWriter, r *http.Request) {
+	if !isReady() {
+		http.Error(w, "not ready", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ready"))
+}
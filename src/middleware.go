@@ -0,0 +1,145 @@
+/*
+AUTHOR: Patrick Archer (@pjarcher913)
+DATE CREATED: 29 July 2026
+Copyright (c) 2026 Patrick Archer
+*/
+
+/*
+Cross-cutting HTTP middleware mounted on the router via initRouter(). Each middleware is a plain
+func(http.Handler) http.Handler so it can be registered with mux's r.Use(), composing cleanly with
+gorilla/handlers.CombinedLoggingHandler.
+*/
+package main
+
+import (
+	"context"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"net"
+	"net/http"
+	"time"
+)
+
+/*====================================================================================================================*/
+
+// ctxKey namespaces values this package stashes on a request's context, so they don't collide with
+// keys set by other packages.
+type ctxKey string
+
+const (
+	ctxKeyRequestID  ctxKey = "requestID"
+	ctxKeyStartTime  ctxKey = "startTime"
+	ctxKeyMethod     ctxKey = "method"
+	ctxKeyPath       ctxKey = "path"
+	ctxKeyRemoteAddr ctxKey = "remoteAddr"
+)
+
+/*====================================================================================================================*/
+
+// requestIDMiddleware() assigns every request a UUID, stashes it on the request context alongside
+// the method/path/remote addr and the time the request started (all consumed by FromContext(), see
+// logger.go), and echoes the id back to the client via the X-Request-Id header so it can be
+// correlated with support tickets.
+func requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := uuid.NewString()
+
+		ctx := context.WithValue(r.Context(), ctxKeyRequestID, id)
+		ctx = context.WithValue(ctx, ctxKeyStartTime, time.Now())
+		ctx = context.WithValue(ctx, ctxKeyMethod, r.Method)
+		ctx = context.WithValue(ctx, ctxKeyPath, r.URL.Path)
+		ctx = context.WithValue(ctx, ctxKeyRemoteAddr, r.RemoteAddr)
+
+		w.Header().Set("X-Request-Id", id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// recoveryMiddleware() recovers from a panic anywhere downstream in the chain (including inside a
+// handler) so that one bad request can't take the whole server down, and responds with a 500
+// instead of the connection simply dying.
+func recoveryMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				log.WithFields(log.Fields{
+					"panic": rec,
+					"path":  r.URL.Path,
+				}).Errorln("Recovered from panic in HTTP handler.")
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// trustedProxyMiddleware() rewrites r.URL.Scheme/r.Host from the X-Forwarded-Proto/X-Forwarded-Host
+// headers, but only for requests whose RemoteAddr appears in trustedProxies — otherwise any client
+// could spoof those headers to influence generated URLs.
+func trustedProxyMiddleware(trustedProxies []string) func(http.Handler) http.Handler {
+	trusted := make(map[string]bool, len(trustedProxies))
+	for _, addr := range trustedProxies {
+		trusted[addr] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			remoteHost, _, err := net.SplitHostPort(r.RemoteAddr)
+			if err != nil {
+				remoteHost = r.RemoteAddr
+			}
+
+			if trusted[remoteHost] {
+				if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+					r.URL.Scheme = proto
+				}
+				if host := r.Header.Get("X-Forwarded-Host"); host != "" {
+					r.Host = host
+					r.URL.Host = host
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// corsMiddleware() applies the configured CORS policy (see CORSConfig in config.go) so the API can
+// be called from browser-based clients hosted on a different origin. allowedOrigins containing "*"
+// allows every origin, as this app always has; otherwise only origins in the list are reflected back,
+// with Vary: Origin so caches don't serve one client's CORS headers to another. Preflight OPTIONS
+// requests are answered directly.
+func corsMiddleware(allowedOrigins []string) func(http.Handler) http.Handler {
+	allowAll := false
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		if origin == "*" {
+			allowAll = true
+			break
+		}
+		allowed[origin] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+
+			if allowAll {
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			} else if origin != "" && allowed[origin] {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+				w.Header().Add("Vary", "Origin")
+			}
+
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-Request-Id")
+
+			if r.Method == http.MethodOptions {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
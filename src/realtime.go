@@ -0,0 +1,224 @@
+/*
+AUTHOR: Patrick Archer (@pjarcher913)
+DATE CREATED: 29 July 2026
+Copyright (c) 2026 Patrick Archer
+*/
+
+/*
+Real-time transport subsystem. A single hub fans published events out to every client subscribed to
+a topic, over either a WebSocket or an SSE connection, mounted at
+/realtime/{sessionid}/websocket and /realtime/{sessionid}/eventsource respectively.
+*/
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+	"sync"
+	"time"
+)
+
+/*====================================================================================================================*/
+
+// How often a heartbeat ping is sent to each connected client to keep idle connections (and any
+// intermediate proxies) from timing them out.
+const REALTIME_HEARTBEAT_INTERVAL = 25 * time.Second
+
+// Default topic every session is subscribed to unless told otherwise.
+const REALTIME_DEFAULT_TOPIC = "home"
+
+var wsUpgrader = websocket.Upgrader{
+	// Handshake timeouts/origin checks are intentionally permissive for now; tighten alongside CORS
+	// config once the app has a real deployment topology (see base-path/reverse-proxy work).
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+/*====================================================================================================================*/
+
+// event is a single message pushed to subscribers of a topic.
+type event struct {
+	Topic   string `json:"topic"`
+	Payload any    `json:"payload"`
+}
+
+// client represents one connected subscriber, addressed by session ID, receiving events on send.
+type client struct {
+	sessionID string
+	topics    map[string]bool
+	send      chan event
+}
+
+// hub tracks every connected client and fans published events out to the ones subscribed to the
+// matching topic.
+type hub struct {
+	mu      sync.Mutex
+	clients map[string]*client
+}
+
+// realtimeHub is the process-wide hub; handlers register/unregister clients against it and
+// Publish() fans events out through it.
+var realtimeHub = &hub{clients: make(map[string]*client)}
+
+/*====================================================================================================================*/
+
+// register() adds a client to the hub, keyed by session ID. A second connection from the same
+// session replaces the first.
+func (h *hub) register(c *client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.clients[c.sessionID] = c
+}
+
+// unregister() removes a client from the hub and closes its send channel, if still present.
+func (h *hub) unregister(c *client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if existing, ok := h.clients[c.sessionID]; ok && existing == c {
+		delete(h.clients, c.sessionID)
+		close(c.send)
+	}
+}
+
+// Publish() pushes payload to every connected client subscribed to topic. Slow/blocked clients are
+// skipped rather than allowed to stall the publisher.
+func Publish(topic string, payload any) {
+	realtimeHub.mu.Lock()
+	defer realtimeHub.mu.Unlock()
+
+	evt := event{Topic: topic, Payload: payload}
+	for _, c := range realtimeHub.clients {
+		if !c.topics[topic] {
+			continue
+		}
+		select {
+		case c.send <- evt:
+		default:
+			log.WithField("session_id", c.sessionID).Warnln("Dropping realtime event for slow client.")
+		}
+	}
+}
+
+/*====================================================================================================================*/
+
+// prh_GET_RealtimeWebSocket() upgrades the connection to a WebSocket and streams events published
+// to REALTIME_DEFAULT_TOPIC (and any topic the client asks for via the "topic" query param) to it,
+// sending a heartbeat ping every REALTIME_HEARTBEAT_INTERVAL.
+func prh_GET_RealtimeWebSocket(w http.ResponseWriter, r *http.Request) {
+	entry := FromContext(r.Context())
+	sessionID := mux.Vars(r)["sessionid"]
+
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		entry.WithError(err).Errorln("Failed to upgrade realtime websocket connection.")
+		return
+	}
+	defer conn.Close()
+
+	c := newRealtimeClient(sessionID, r)
+	realtimeHub.register(c)
+	defer realtimeHub.unregister(c)
+
+	go pumpWebSocketReads(conn) // drains client pings/close frames so writes don't back up
+
+	ticker := time.NewTicker(REALTIME_HEARTBEAT_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case evt, ok := <-c.send:
+			if !ok {
+				return
+			}
+			if err := conn.WriteJSON(evt); err != nil {
+				entry.WithError(err).Debugln("Realtime websocket write failed, closing.")
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// pumpWebSocketReads() discards any message a client sends (this is a server-push channel) but
+// still needs to read so the gorilla/websocket connection notices a close frame or I/O error.
+func pumpWebSocketReads(conn *websocket.Conn) {
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+// prh_GET_RealtimeEventSource() streams events published to REALTIME_DEFAULT_TOPIC to the client as
+// Server-Sent Events, for browsers/environments where a WebSocket isn't available.
+func prh_GET_RealtimeEventSource(w http.ResponseWriter, r *http.Request) {
+	entry := FromContext(r.Context())
+	sessionID := mux.Vars(r)["sessionid"]
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	c := newRealtimeClient(sessionID, r)
+	realtimeHub.register(c)
+	defer realtimeHub.unregister(c)
+
+	ticker := time.NewTicker(REALTIME_HEARTBEAT_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case evt, ok := <-c.send:
+			if !ok {
+				return
+			}
+			payload, err := json.Marshal(evt)
+			if err != nil {
+				entry.WithError(err).Errorln("Failed to marshal SSE event.")
+				continue
+			}
+			fmt.Fprintf(w, "data: %s\n\n", payload)
+			flusher.Flush()
+		case <-ticker.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// newRealtimeClient() builds a client subscribed to REALTIME_DEFAULT_TOPIC plus whatever topic the
+// request's "topic" query param names, if any.
+func newRealtimeClient(sessionID string, r *http.Request) *client {
+	topics := map[string]bool{REALTIME_DEFAULT_TOPIC: true}
+	if t := r.URL.Query().Get("topic"); t != "" {
+		topics[t] = true
+	}
+	return &client{
+		sessionID: sessionID,
+		topics:    topics,
+		send:      make(chan event, 8),
+	}
+}
+
+/*====================================================================================================================*/
+
+func init() {
+	RegisterRoutes(func(r *mux.Router) {
+		r.HandleFunc("/realtime/{sessionid}/websocket", prh_GET_RealtimeWebSocket).Methods("GET")
+		r.HandleFunc("/realtime/{sessionid}/eventsource", prh_GET_RealtimeEventSource).Methods("GET")
+	})
+}
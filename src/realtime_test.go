@@ -0,0 +1,79 @@
+/*
+AUTHOR: Patrick Archer (@pjarcher913)
+DATE CREATED: 29 July 2026
+Copyright (c) 2026 Patrick Archer
+*/
+
+package main
+
+import "testing"
+
+/*====================================================================================================================*/
+
+// withTestHub swaps realtimeHub for an empty one for the duration of a test, restoring the original
+// afterward so tests don't leak clients into (or race with) the process-wide hub.
+func withTestHub(t *testing.T) *hub {
+	t.Helper()
+	orig := realtimeHub
+	testHub := &hub{clients: make(map[string]*client)}
+	realtimeHub = testHub
+	t.Cleanup(func() { realtimeHub = orig })
+	return testHub
+}
+
+// Registering a second client for a session ID that's already connected should replace the first.
+// Publish() must only deliver to the replacement, not the stale client it replaced.
+func TestHubRegisterReplacePublishesOnlyToLiveClient(t *testing.T) {
+	h := withTestHub(t)
+
+	stale := &client{sessionID: "sess-1", topics: map[string]bool{"home": true}, send: make(chan event, 1)}
+	live := &client{sessionID: "sess-1", topics: map[string]bool{"home": true}, send: make(chan event, 1)}
+
+	h.register(stale)
+	h.register(live)
+
+	Publish("home", "hello")
+
+	select {
+	case evt := <-live.send:
+		if evt.Topic != "home" || evt.Payload != "hello" {
+			t.Fatalf("live client got unexpected event: %+v", evt)
+		}
+	default:
+		t.Fatal("expected the live client to receive the published event")
+	}
+
+	select {
+	case evt := <-stale.send:
+		t.Fatalf("stale, replaced client should not receive events, got: %+v", evt)
+	default:
+	}
+}
+
+// unregister() of a client that's already been replaced by a newer registration for the same
+// session ID must be a no-op: it must not close the live client's send channel or remove it from
+// the hub.
+func TestHubUnregisterAfterReplaceIsNoop(t *testing.T) {
+	h := withTestHub(t)
+
+	stale := &client{sessionID: "sess-1", topics: map[string]bool{"home": true}, send: make(chan event, 1)}
+	live := &client{sessionID: "sess-1", topics: map[string]bool{"home": true}, send: make(chan event, 1)}
+
+	h.register(stale)
+	h.register(live)
+
+	h.unregister(stale)
+
+	if _, ok := h.clients["sess-1"]; !ok {
+		t.Fatal("unregister() of a stale client removed the live client from the hub")
+	}
+
+	select {
+	case _, ok := <-live.send:
+		if !ok {
+			t.Fatal("unregister() of a stale client closed the live client's send channel")
+		}
+	default:
+		// Open and empty, as expected.
+	}
+}
@@ -0,0 +1,115 @@
+/*
+AUTHOR: Patrick Archer (@pjarcher913)
+DATE CREATED: 29 July 2026
+Copyright (c) 2026 Patrick Archer
+*/
+
+/*
+Builds the site's route hierarchy: JSON endpoints under /api/v1, HTML pages at the root, and static
+assets under /static/. Also exposes two extension points: RegisterRoutes() for app routes (mounted
+under routeConfig.BasePath, like everything else above) and RegisterRootRoutes() for infra routes
+(health checks, metrics scraping) that must stay reachable directly against the pod/process and so
+are mounted on the root router regardless of any configured base path.
+*/
+package main
+
+import (
+	"github.com/gorilla/handlers"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+/*====================================================================================================================*/
+
+// Filesystem root that /static/ is served out of. Note this is the static subtree, not all of
+// ./src/web/ — the "static/" URL segment is stripped before lookup, so the directory root has to
+// match what's left (e.g. "/static/css/main.css" -> "css/main.css" -> STATIC_DIR+"css/main.css").
+const STATIC_DIR = "./src/web/static/"
+
+// RouteRegistrar mounts additional handlers onto a router. Feature packages register one via
+// RegisterRoutes() or RegisterRootRoutes() instead of editing initRouter() directly.
+type RouteRegistrar func(r *mux.Router)
+
+// routeRegistrars holds every RouteRegistrar added via RegisterRoutes(), applied to appRouter (i.e.
+// under routeConfig.BasePath) in initRouter().
+var routeRegistrars []RouteRegistrar
+
+// rootRouteRegistrars holds every RouteRegistrar added via RegisterRootRoutes(), applied to the root
+// router (i.e. ignoring routeConfig.BasePath) in initRouter().
+var rootRouteRegistrars []RouteRegistrar
+
+/*====================================================================================================================*/
+
+// RegisterRoutes() adds a RouteRegistrar to be applied to appRouter the next time initRouter() runs,
+// letting feature packages mount their own app routes without main.go needing to know about them
+// ahead of time. Routes registered this way sit behind routeConfig.BasePath like everything else in
+// initRouter().
+func RegisterRoutes(registrar RouteRegistrar) {
+	routeRegistrars = append(routeRegistrars, registrar)
+}
+
+// RegisterRootRoutes() adds a RouteRegistrar to be applied to the root router the next time
+// initRouter() runs, bypassing routeConfig.BasePath. Use this for infra endpoints (health checks,
+// metrics scraping) that orchestrators/monitoring talk to directly rather than through whatever
+// reverse proxy the base path is for.
+func RegisterRootRoutes(registrar RouteRegistrar) {
+	rootRouteRegistrars = append(rootRouteRegistrars, registrar)
+}
+
+// initRouter() initializes Mux's routing services and configures them according to the website's
+// page route hierarchy: /api/v1 for JSON endpoints, / for HTML pages, and /static/ for assets, all
+// mounted under routeConfig.BasePath so the app can live behind a reverse proxy at a non-root URL.
+func initRouter() *mux.Router {
+	log.Infoln("Executing initRouter().")
+
+	// Init mux router object
+	r := mux.NewRouter()
+
+	// Middleware chain applied to every request. Registered outermost-first: recovery wraps
+	// everything so a panic anywhere downstream is still caught, then forwarded-proto/host are
+	// trusted (or not) before anything builds a URL from them, then request IDs, then metrics, then
+	// CORS, then the access log.
+	r.Use(recoveryMiddleware)
+	r.Use(trustedProxyMiddleware(routeConfig.TrustedProxies))
+	r.Use(requestIDMiddleware)
+	r.Use(metricsMiddleware)
+	r.Use(corsMiddleware(corsConfig.AllowedOrigins))
+	r.Use(func(next http.Handler) http.Handler {
+		return handlers.CombinedLoggingHandler(log.StandardLogger().Out, next)
+	})
+
+	// 404
+	// TODO: Custom 404 error route handler
+	//r.NotFoundHandler = http.HandlerFunc(prh_404)
+
+	// Every actual route is mounted on appRouter, which is r itself unless a base path is
+	// configured, in which case it's a subrouter under that prefix.
+	appRouter := r
+	if routeConfig.BasePath != "" {
+		appRouter = r.PathPrefix(routeConfig.BasePath).Subrouter()
+	}
+
+	/* JSON API, versioned so breaking changes can live alongside /api/v1 as /api/v2 */
+	apiV1 := appRouter.PathPrefix("/api/v1").Subrouter()
+	apiV1.HandleFunc("/{rootParam}", prh_POST_Home).Methods("POST", "OPTIONS") // OPTIONS: CORS preflight, answered by corsMiddleware()
+
+	/* HTML pages */
+	appRouter.HandleFunc("/", prh_GET_Home).Methods("GET")
+
+	/* Static assets (CSS/JS/images) */
+	appRouter.PathPrefix("/static/").Handler(http.StripPrefix(routeConfig.BasePath+"/static/", http.FileServer(http.Dir(STATIC_DIR))))
+
+	// Let feature packages mount whatever routes they registered via RegisterRoutes()
+	for _, registrar := range routeRegistrars {
+		registrar(appRouter)
+	}
+
+	// Infra routes (health checks, metrics scraping) stay on the root router, unaffected by
+	// routeConfig.BasePath
+	for _, registrar := range rootRouteRegistrars {
+		registrar(r)
+	}
+
+	return r
+}
@@ -0,0 +1,57 @@
+/*
+AUTHOR: Patrick Archer (@pjarcher913)
+DATE CREATED: 29 July 2026
+Copyright (c) 2026 Patrick Archer
+*/
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+/*====================================================================================================================*/
+
+// chdirRepoRoot() changes the working directory to the repo root for the duration of a test and
+// restores it afterward. STATIC_DIR/CONFIG_PATH/LOG_PATH are all relative to that root (matching how
+// the binary is actually run), not to the package directory `go test` uses by default.
+func chdirRepoRoot(t *testing.T) {
+	t.Helper()
+
+	_, thisFile, _, ok := runtime.Caller(0)
+	if !ok {
+		t.Fatal("failed to determine test file location")
+	}
+	repoRoot := filepath.Dir(filepath.Dir(thisFile)) // src/router_test.go -> repo root
+
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(repoRoot); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(orig) })
+}
+
+// Regression test for a bug where STATIC_DIR pointed at ./src/web/ while the "/static/" URL prefix
+// (and therefore the stripped prefix) assumed the filesystem root already excluded the "static/"
+// segment, so every asset under /static/ 404'd.
+func TestStaticAssetsServedFromCorrectDirectory(t *testing.T) {
+	chdirRepoRoot(t)
+
+	r := initRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/static/css/main.css", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /static/css/main.css = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+AUTHOR: Patrick Archer (@pjarcher913)
+DATE CREATED: 29 July 2026
+Copyright (c) 2026 Patrick Archer
+*/
+
+/*
+This file owns the *http.Server lifecycle: building it from ServerConfig, starting it, and bringing
+it down cleanly on SIGINT/SIGTERM so registered shutdown hooks (closing the log file, flushing
+caches, ...) get a chance to run instead of the process just dying mid-request.
+*/
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+	"os/signal"
+	"syscall"
+)
+
+/*====================================================================================================================*/
+
+// shutdownHook is a no-arg cleanup func run once, in registration order, during graceful shutdown.
+type shutdownHook func()
+
+// shutdownHooks accumulates cleanup funcs registered via OnShutdown(), e.g. from prelimSetup().
+var shutdownHooks []shutdownHook
+
+/*====================================================================================================================*/
+
+// OnShutdown() registers a cleanup func to run after the server has stopped accepting new
+// connections but before the process exits. Hooks run in the order they were registered.
+func OnShutdown(hook shutdownHook) {
+	shutdownHooks = append(shutdownHooks, hook)
+}
+
+// runShutdownHooks() invokes every registered shutdown hook, logging (rather than panicking on) any
+// hook that misbehaves so one bad cleanup func can't prevent the others from running.
+func runShutdownHooks() {
+	for _, hook := range shutdownHooks {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.WithField("panic", r).Errorln("Recovered from panic in shutdown hook.")
+				}
+			}()
+			hook()
+		}()
+	}
+}
+
+// newHTTPServer() builds an *http.Server from cfg and routeHandler, applying the configured
+// Read/Write/Idle timeouts so slow or idle clients can't hold a connection open indefinitely.
+func newHTTPServer(cfg ServerConfig, routeHandler *mux.Router) *http.Server {
+	return &http.Server{
+		Addr:         cfg.Port,
+		Handler:      routeHandler,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
+	}
+}
+
+// initWebServer() initializes the web server and begins serving clients connecting to the
+// configured port. It blocks until the process receives SIGINT/SIGTERM, at which point it stops
+// accepting new connections, waits up to cfg.ShutdownGrace for in-flight requests to finish, and
+// runs any registered shutdown hooks before returning.
+func initWebServer(routeHandler *mux.Router) {
+	log.Infoln("Executing initWebServer().")
+
+	cfg := loadServerConfig()
+	srv := newHTTPServer(cfg, routeHandler)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// serveErr carries a ListenAndServe() failure (e.g. port already in use) back to this goroutine,
+	// so a startup failure still drains through runShutdownHooks() instead of os.Exit()-ing from
+	// inside the listener goroutine and skipping cleanup.
+	serveErr := make(chan error, 1)
+
+	go func() {
+		fmt.Println("Now serving on 127.0.0.1" + cfg.Port)
+		log.Infoln("Now serving on 127.0.0.1" + cfg.Port)
+		// http.ListenAndServe() always returns a non-nil error, and the error is its only returned
+		// value. http.ErrServerClosed is expected here; it just means Shutdown() was called.
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			serveErr <- err
+		}
+	}()
+
+	select {
+	case err := <-serveErr:
+		log.WithError(err).Errorln("initWebServer() failed to start, running shutdown hooks before exit.")
+		runShutdownHooks()
+		log.Fatalln(err.Error())
+	case <-ctx.Done():
+		stop()
+		log.Infoln("Shutdown signal received, draining in-flight requests.")
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownGrace)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.WithError(err).Errorln("Error during graceful shutdown, forcing close.")
+		_ = srv.Close()
+	}
+
+	runShutdownHooks()
+	log.Infoln("Shutdown complete.")
+}